@@ -0,0 +1,45 @@
+package client
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// TxConfig defines an interface a client can utilize to generate an application-specific
+// transaction type. The transaction types, and its related encoding and decoding mechanisms, are
+// all determined by a given client.
+type TxConfig interface {
+	TxEncodingConfig
+
+	NewTxBuilder() TxBuilder
+	WrapTxBuilder(sdk.Tx) (TxBuilder, error)
+
+	SignModeHandler() signing.SignModeHandler
+}
+
+// TxEncodingConfig defines the encoding and decoding methods that are used to marshal and
+// unmarshal transactions between their in-memory and binary/JSON wire representations.
+type TxEncodingConfig interface {
+	TxEncoder() sdk.TxEncoder
+	TxDecoder() sdk.TxDecoder
+	TxJSONEncoder() sdk.TxEncoder
+	TxJSONDecoder() sdk.TxDecoder
+}
+
+// TxStreamConfig is an optional capability a TxConfig implementation may provide in addition to
+// TxEncodingConfig. It is kept as a separate interface, rather than folded into TxEncodingConfig,
+// so that adding it does not break every existing TxConfig implementation (mocks, test doubles,
+// hand-rolled app/chain configs) that has no use for streaming. Callers that want it should do:
+//
+//	if sc, ok := txConfig.(client.TxStreamConfig); ok {
+//		iter := tx.NewTxIterator(r, sc.TxStreamDecoder())
+//		...
+//	}
+type TxStreamConfig interface {
+	// TxStreamEncoder and TxStreamDecoder are the streaming counterparts to TxEncoder/TxDecoder.
+	// They write/read one transaction at a time against an io.Writer/io.Reader instead of
+	// requiring the full batch to be held in memory, for tooling that processes genesis exports,
+	// block archives, or large indexer backfills.
+	TxStreamEncoder() sdk.TxStreamEncoder
+	TxStreamDecoder() sdk.TxStreamDecoder
+}
@@ -0,0 +1,15 @@
+package types
+
+import "io"
+
+// TxStreamEncoder writes a single transaction to w. Unlike TxEncoder it writes directly to the
+// destination instead of returning a byte slice, so repeated calls with the same w append
+// transactions to the stream without buffering them all in memory. It lives alongside TxEncoder
+// rather than in x/auth/tx so that client.TxStreamConfig can reference it without client importing
+// x/auth/tx, which itself imports client.
+type TxStreamEncoder func(w io.Writer, tx Tx) error
+
+// TxStreamDecoder reads a single transaction from r. Unlike TxDecoder it does not require the
+// full input to be buffered in memory beforehand, so it can be called repeatedly on the same r to
+// pull successive transactions out of a stream.
+type TxStreamDecoder func(r io.Reader) (Tx, error)
@@ -0,0 +1,250 @@
+package tx
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	gogoproto "github.com/cosmos/gogoproto/proto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	signingtypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// TxCacheEvictionPolicy selects how a TxCacheConfig reclaims entries once it reaches its Size.
+type TxCacheEvictionPolicy int
+
+const (
+	// TxCacheEvictLRU evicts the least recently used entry. It is the zero value and, for now,
+	// the only supported policy.
+	TxCacheEvictLRU TxCacheEvictionPolicy = iota
+)
+
+// TxCacheMetrics receives cache hit/miss notifications so callers can export hit/miss ratios.
+// Implementations must be safe for concurrent use, since a TxConfig - and therefore its cache -
+// is shared across goroutines (e.g. concurrent CheckTx calls).
+type TxCacheMetrics interface {
+	IncTxDecodeHit()
+	IncTxDecodeMiss()
+	IncSignBytesHit()
+	IncSignBytesMiss()
+}
+
+// TxCacheConfig enables and configures the optional content-addressed tx cache installed via
+// ConfigOptions.TxCache. The cache memoizes:
+//
+//   - decoded sdk.Tx values, keyed by sha256(raw tx bytes);
+//   - sign bytes computed by each enabled SignModeHandler, keyed by
+//     a hash of the tx bytes as re-encoded by the TxConfig's encoder + signer data (address,
+//     chain ID, account number, sequence) + sign mode.
+//
+// Both keys are a pure function of immutable inputs, so no explicit invalidation is needed: a
+// given key always maps to the same value, and entries simply age out of the LRU once Size is
+// exceeded. This is intended to cut CPU on high-throughput chains, where SIGN_MODE_TEXTUAL and
+// SIGN_MODE_LEGACY_AMINO_JSON sign bytes are otherwise recomputed on every ante-handler pass and
+// every CheckTx/ReCheckTx/DeliverTx.
+//
+// The *value* stored for a decoded tx is not immutable, though: client.TxConfig.WrapTxBuilder
+// hands back a mutable builder over the very same tx it is given, and callers routinely mutate a
+// decoded tx in place (e.g. to bump gas or append a signature before resubmitting it). The decode
+// cache therefore deep-copies on every read (and on the write that populates a miss) so a mutation
+// by one caller can never corrupt the entry shared with other callers or concurrent
+// CheckTx/ReCheckTx/DeliverTx decodes of the same byte-identical tx. A decoded tx that does not
+// implement gogoproto's Message interface is not copied (see cloneDecodedTx) and is shared.
+type TxCacheConfig struct {
+	// Size is the maximum number of entries retained by each of the decode cache and the sign
+	// bytes cache before EvictionPolicy reclaims space. Size <= 0 disables caching.
+	Size int
+	// EvictionPolicy selects how entries are reclaimed once Size is reached.
+	EvictionPolicy TxCacheEvictionPolicy
+	// Metrics, if non-nil, is notified of every cache hit and miss.
+	Metrics TxCacheMetrics
+}
+
+// lruCache is a fixed-size, least-recently-used cache keyed by string. All methods are safe for
+// concurrent use: a single mutex guards the list and map, and is never held across the caller's
+// own (comparatively expensive) work that populates a miss.
+type lruCache struct {
+	size int
+
+	mu sync.Mutex
+	ll *list.List
+	m  map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value interface{}
+}
+
+func newLRUCache(size int) *lruCache {
+	return &lruCache{size: size, ll: list.New(), m: make(map[string]*list.Element, size)}
+}
+
+func (c *lruCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.m[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return el.Value.(*lruEntry).value, true
+}
+
+func (c *lruCache) put(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.m[key]; ok {
+		c.ll.MoveToFront(el)
+		el.Value.(*lruEntry).value = value
+		return
+	}
+
+	el := c.ll.PushFront(&lruEntry{key: key, value: value})
+	c.m[key] = el
+
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.m, oldest.Value.(*lruEntry).key)
+		}
+	}
+}
+
+// hashFields derives a single content hash from an ordered list of byte fields, length-prefixing
+// each one before hashing. This is deliberate: joining variable-length fields with a delimiter
+// (e.g. "|") lets two distinct field tuples stringify to the same key whenever a field can itself
+// contain the delimiter - a chain ID is not restricted to a safe charset, for instance - silently
+// colliding two unrelated cache entries. Length-prefixing removes the ambiguity entirely.
+func hashFields(fields ...[]byte) [32]byte {
+	h := sha256.New()
+	var lenBuf [8]byte
+	for _, f := range fields {
+		binary.BigEndian.PutUint64(lenBuf[:], uint64(len(f)))
+		h.Write(lenBuf[:])
+		h.Write(f)
+	}
+	var sum [32]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+func uint64Bytes(n uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], n)
+	return buf[:]
+}
+
+// withCachedDecoder wraps decode so that repeated calls with byte-identical raw tx bytes are
+// served from the previously decoded sdk.Tx instead of re-unmarshaling it. Every value handed
+// back to the caller - on both a hit and the decode that populates a miss - is a deep copy of the
+// cached entry (see cloneDecodedTx), so a caller mutating its result (directly, or via
+// client.TxConfig.WrapTxBuilder) can never corrupt the cache. A decoded tx that does not implement
+// gogoproto's Message interface is not copied: every caller decoding the same bytes shares it.
+func withCachedDecoder(decode sdk.TxDecoder, cacheCfg TxCacheConfig) sdk.TxDecoder {
+	cache := newLRUCache(cacheCfg.Size)
+
+	return func(txBytes []byte) (sdk.Tx, error) {
+		sum := sha256.Sum256(txBytes)
+		key := string(sum[:])
+
+		if v, ok := cache.get(key); ok {
+			if cacheCfg.Metrics != nil {
+				cacheCfg.Metrics.IncTxDecodeHit()
+			}
+			cachedTx, _ := v.(sdk.Tx)
+			return cloneDecodedTx(cachedTx), nil
+		}
+		if cacheCfg.Metrics != nil {
+			cacheCfg.Metrics.IncTxDecodeMiss()
+		}
+
+		decoded, err := decode(txBytes)
+		if err != nil {
+			return nil, err
+		}
+		cache.put(key, decoded)
+		return cloneDecodedTx(decoded), nil
+	}
+}
+
+// cloneDecodedTx returns a deep copy of tx via proto reflection, so the copy stored in (or read
+// from) the decode cache never aliases a value a caller can mutate. Falls back to returning tx
+// itself if it does not implement gogoproto's Message interface, since then there is nothing this
+// package can safely introspect to copy.
+func cloneDecodedTx(tx sdk.Tx) sdk.Tx {
+	msg, ok := tx.(gogoproto.Message)
+	if !ok {
+		return tx
+	}
+
+	clone, ok := gogoproto.Clone(msg).(sdk.Tx)
+	if !ok {
+		return tx
+	}
+	return clone
+}
+
+// cachingSignModeHandler decorates a signing.SignModeHandler so that GetSignBytes memoizes its
+// result. encode is used only to derive the cache key (a cheap proto re-marshal) and never
+// substitutes for the wrapped handler's own sign bytes computation.
+type cachingSignModeHandler struct {
+	signing.SignModeHandler
+	encode  sdk.TxEncoder
+	cache   *lruCache
+	metrics TxCacheMetrics
+}
+
+// withCachedSignModeHandler wraps handler so that GetSignBytes is memoized per a content hash of
+// (tx bytes as re-encoded by encode, signer address, chain ID, account number, sequence, sign
+// mode) - see hashFields.
+func withCachedSignModeHandler(handler signing.SignModeHandler, encode sdk.TxEncoder, cacheCfg TxCacheConfig) signing.SignModeHandler {
+	return &cachingSignModeHandler{
+		SignModeHandler: handler,
+		encode:          encode,
+		cache:           newLRUCache(cacheCfg.Size),
+		metrics:         cacheCfg.Metrics,
+	}
+}
+
+func (c *cachingSignModeHandler) GetSignBytes(mode signingtypes.SignMode, data signing.SignerData, tx sdk.Tx) ([]byte, error) {
+	bz, err := c.encode(tx)
+	if err != nil {
+		// The tx can't be re-encoded to derive a cache key (e.g. a builder still under
+		// construction); fall back to the uncached computation rather than failing the request.
+		return c.SignModeHandler.GetSignBytes(mode, data, tx)
+	}
+
+	sum := hashFields(
+		bz,
+		[]byte(data.Address),
+		[]byte(data.ChainID),
+		uint64Bytes(data.AccountNumber),
+		uint64Bytes(data.Sequence),
+		[]byte(mode.String()),
+	)
+	key := string(sum[:])
+
+	if v, ok := c.cache.get(key); ok {
+		if c.metrics != nil {
+			c.metrics.IncSignBytesHit()
+		}
+		return v.([]byte), nil
+	}
+	if c.metrics != nil {
+		c.metrics.IncSignBytesMiss()
+	}
+
+	out, err := c.SignModeHandler.GetSignBytes(mode, data, tx)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.put(key, out)
+	return out, nil
+}
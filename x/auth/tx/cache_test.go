@@ -0,0 +1,141 @@
+package tx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	protov2 "google.golang.org/protobuf/proto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	signingtypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// mutableCacheTestTx is a minimal gogoproto.Message standing in for the real *wrapper tx, which
+// client.TxConfig.WrapTxBuilder hands back as a mutable builder over the very value it was given.
+// It exists so TestWithCachedDecoderClonesOnMutation can exercise that mutation pattern without
+// depending on *wrapper (defined outside this package's sources).
+type mutableCacheTestTx struct {
+	Field string
+}
+
+func (t *mutableCacheTestTx) Reset()         { t.Field = "" }
+func (t *mutableCacheTestTx) String() string { return t.Field }
+func (t *mutableCacheTestTx) ProtoMessage()  {}
+func (t *mutableCacheTestTx) GetMsgs() []sdk.Msg                        { return nil }
+func (t *mutableCacheTestTx) GetMsgsV2() ([]protov2.Message, error)     { return nil, nil }
+
+// fakeSignModeHandler returns sign bytes that deterministically encode the SignerData it was
+// called with, so a test can detect whether two distinct SignerData values were served the same
+// (incorrectly) cached result.
+type fakeSignModeHandler struct {
+	calls int
+}
+
+func (h *fakeSignModeHandler) DefaultMode() signingtypes.SignMode {
+	return signingtypes.SignMode_SIGN_MODE_DIRECT
+}
+
+func (h *fakeSignModeHandler) Modes() []signingtypes.SignMode {
+	return []signingtypes.SignMode{signingtypes.SignMode_SIGN_MODE_DIRECT}
+}
+
+func (h *fakeSignModeHandler) GetSignBytes(mode signingtypes.SignMode, data signing.SignerData, _ sdk.Tx) ([]byte, error) {
+	h.calls++
+	return []byte(fmt.Sprintf("%s|%s|%s|%d|%d", mode, data.Address, data.ChainID, data.AccountNumber, data.Sequence)), nil
+}
+
+func TestHashFieldsNoDelimiterCollision(t *testing.T) {
+	a := hashFields([]byte("ab"), []byte("c"))
+	b := hashFields([]byte("a"), []byte("bc"))
+	require.NotEqual(t, a, b)
+}
+
+func TestCachingSignModeHandlerDistinctSignerDataNotConflated(t *testing.T) {
+	inner := &fakeSignModeHandler{}
+	encode := func(sdk.Tx) ([]byte, error) { return []byte("same-tx-bytes"), nil }
+	handler := withCachedSignModeHandler(inner, encode, TxCacheConfig{Size: 10})
+
+	base := signing.SignerData{Address: "addr", ChainID: "chain-a", AccountNumber: 1, Sequence: 1}
+	diffChainID := base
+	diffChainID.ChainID = "chain-b"
+	diffAccount := base
+	diffAccount.AccountNumber = 2
+	diffSequence := base
+	diffSequence.Sequence = 2
+
+	bz1, err := handler.GetSignBytes(signingtypes.SignMode_SIGN_MODE_DIRECT, base, nil)
+	require.NoError(t, err)
+	bz2, err := handler.GetSignBytes(signingtypes.SignMode_SIGN_MODE_DIRECT, diffChainID, nil)
+	require.NoError(t, err)
+	bz3, err := handler.GetSignBytes(signingtypes.SignMode_SIGN_MODE_DIRECT, diffAccount, nil)
+	require.NoError(t, err)
+	bz4, err := handler.GetSignBytes(signingtypes.SignMode_SIGN_MODE_DIRECT, diffSequence, nil)
+	require.NoError(t, err)
+
+	// Every distinct SignerData tuple must reach the underlying handler: none of them may be
+	// served from a cache entry populated by one of the others.
+	require.Equal(t, 4, inner.calls)
+	require.NotEqual(t, bz1, bz2)
+	require.NotEqual(t, bz1, bz3)
+	require.NotEqual(t, bz1, bz4)
+}
+
+func TestCachingSignModeHandlerCacheHit(t *testing.T) {
+	inner := &fakeSignModeHandler{}
+	encode := func(sdk.Tx) ([]byte, error) { return []byte("same-tx-bytes"), nil }
+	handler := withCachedSignModeHandler(inner, encode, TxCacheConfig{Size: 10})
+
+	data := signing.SignerData{Address: "addr", ChainID: "chain-a", AccountNumber: 1, Sequence: 1}
+
+	bz1, err := handler.GetSignBytes(signingtypes.SignMode_SIGN_MODE_DIRECT, data, nil)
+	require.NoError(t, err)
+	bz2, err := handler.GetSignBytes(signingtypes.SignMode_SIGN_MODE_DIRECT, data, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, inner.calls)
+	require.Equal(t, bz1, bz2)
+}
+
+// TestWithCachedDecoderClonesOnMutation simulates a caller that decodes a tx, mutates the result
+// in place (as client.TxConfig.WrapTxBuilder's caller would, e.g. to bump gas or append a
+// signature before resubmitting), and then decodes the same byte-identical tx again. The second
+// decode must be unaffected by the first caller's mutation.
+func TestWithCachedDecoderClonesOnMutation(t *testing.T) {
+	decode := func([]byte) (sdk.Tx, error) {
+		return &mutableCacheTestTx{Field: "original"}, nil
+	}
+	cached := withCachedDecoder(decode, TxCacheConfig{Size: 10})
+
+	first, err := cached([]byte("tx-bytes"))
+	require.NoError(t, err)
+
+	firstMutable, ok := first.(*mutableCacheTestTx)
+	require.True(t, ok)
+	firstMutable.Field = "mutated-by-caller-one"
+
+	second, err := cached([]byte("tx-bytes"))
+	require.NoError(t, err)
+
+	secondMutable, ok := second.(*mutableCacheTestTx)
+	require.True(t, ok)
+	require.Equal(t, "original", secondMutable.Field)
+	require.NotSame(t, firstMutable, secondMutable)
+}
+
+func TestWithCachedDecoderHit(t *testing.T) {
+	calls := 0
+	decode := func([]byte) (sdk.Tx, error) {
+		calls++
+		return nil, nil
+	}
+	cached := withCachedDecoder(decode, TxCacheConfig{Size: 10})
+
+	_, err := cached([]byte("tx-bytes"))
+	require.NoError(t, err)
+	_, err = cached([]byte("tx-bytes"))
+	require.NoError(t, err)
+
+	require.Equal(t, 1, calls)
+}
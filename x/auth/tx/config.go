@@ -2,34 +2,141 @@ package tx
 
 import (
 	"fmt"
+	"sync"
+
+	txsigning "cosmossdk.io/x/tx/signing"
+	"cosmossdk.io/x/tx/signing/aminojson"
+	"cosmossdk.io/x/tx/signing/direct"
+	directaux "cosmossdk.io/x/tx/signing/direct_aux"
+	"cosmossdk.io/x/tx/signing/textual"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	signingtypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authcodec "github.com/cosmos/cosmos-sdk/x/auth/codec"
 	"github.com/cosmos/cosmos-sdk/x/auth/signing"
 )
 
+// DefaultSignModes are the default sign modes enabled for protobuf driven configurations.
+var DefaultSignModes = []signingtypes.SignMode{
+	signingtypes.SignMode_SIGN_MODE_DIRECT,
+	signingtypes.SignMode_SIGN_MODE_DIRECT_AUX,
+	signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+}
+
+// ConfigOptions define the configuration of a TxConfig when calling NewTxConfigWithOptions.
+type ConfigOptions struct {
+	// EnabledSignModes is the list of sign modes that will have a corresponding handler
+	// resolved via the sign mode registry populated by RegisterSignMode. If empty,
+	// DefaultSignModes is used.
+	EnabledSignModes []signingtypes.SignMode
+	// CustomSignModes is a catch-all escape hatch for supplying fully constructed handlers
+	// without registering them via RegisterSignMode. Prefer RegisterSignMode for sign modes
+	// that should be reusable across TxConfig constructions (e.g. a chain-wide EIP-712 mode).
+	CustomSignModes []txsigning.SignModeHandler
+	// SigningOptions are used to configure the signing context for the enabled sign modes.
+	SigningOptions *txsigning.Options
+	// SigningContext, if set, takes precedence over SigningOptions when constructing sign mode
+	// handlers.
+	SigningContext *txsigning.Context
+	// TextualCoinMetadataQueryFn must be set to enable SIGN_MODE_TEXTUAL.
+	TextualCoinMetadataQueryFn textual.CoinMetadataQueryFn
+	// ProtoDecoder and ProtoEncoder override the default protobuf tx decoder/encoder.
+	ProtoDecoder sdk.TxDecoder
+	ProtoEncoder sdk.TxEncoder
+	// JSONDecoder and JSONEncoder override the default JSON tx decoder/encoder.
+	JSONDecoder sdk.TxDecoder
+	JSONEncoder sdk.TxEncoder
+	// TxCache, if non-nil, enables the content-addressed tx cache described by TxCacheConfig,
+	// wired into TxDecoder and every enabled SignModeHandler's GetSignBytes.
+	TxCache *TxCacheConfig
+}
+
+// SignModeHandlerFactory constructs a txsigning.SignModeHandler for a registered sign mode from
+// the ConfigOptions a TxConfig is being built with. It is the extension point used by
+// RegisterSignMode to add sign modes that are unknown to this package, such as SIGN_MODE_EIP_191
+// or SIGN_MODE_EIP_712 on an EVM-compatible chain.
+type SignModeHandlerFactory func(configOpts ConfigOptions) (txsigning.SignModeHandler, error)
+
+// TxBuilderHook lets a registered sign mode attach mode-specific fields to a client.TxBuilder,
+// e.g. an EIP-712 typed-data domain. It is applied, in the order the owning sign modes are listed
+// in ConfigOptions.EnabledSignModes, to every TxBuilder returned by config.NewTxBuilder or
+// config.WrapTxBuilder whenever the owning sign mode is enabled.
+type TxBuilderHook func(client.TxBuilder) client.TxBuilder
+
+type signModeRegistration struct {
+	factory     SignModeHandlerFactory
+	builderHook TxBuilderHook
+}
+
+var (
+	signModeRegistryMu sync.RWMutex
+	signModeRegistry   = map[signingtypes.SignMode]signModeRegistration{}
+)
+
+// RegisterSignMode adds or replaces the factory (and optional builderHook) used to build the
+// SignModeHandler for mode whenever mode is listed in ConfigOptions.EnabledSignModes. Downstream
+// chains call this - typically from an init() - to add sign modes without forking this package
+// or relying on the catch-all ConfigOptions.CustomSignModes list.
+//
+// builderHook may be nil. When non-nil, it is invoked on every client.TxBuilder returned by
+// config.NewTxBuilder whenever mode is enabled, letting the hook attach mode-specific fields to
+// the builder (e.g. an EIP-712 typed-data domain).
+func RegisterSignMode(mode signingtypes.SignMode, factory SignModeHandlerFactory, builderHook TxBuilderHook) {
+	signModeRegistryMu.Lock()
+	defer signModeRegistryMu.Unlock()
+	signModeRegistry[mode] = signModeRegistration{factory: factory, builderHook: builderHook}
+}
+
+func init() {
+	RegisterSignMode(signingtypes.SignMode_SIGN_MODE_DIRECT, func(ConfigOptions) (txsigning.SignModeHandler, error) {
+		return &direct.SignModeHandler{}, nil
+	}, nil)
+
+	RegisterSignMode(signingtypes.SignMode_SIGN_MODE_DIRECT_AUX, func(configOpts ConfigOptions) (txsigning.SignModeHandler, error) {
+		return directaux.NewSignModeHandler(directaux.SignModeHandlerOptions{
+			TypeResolver:   configOpts.SigningOptions.TypeResolver,
+			SignersContext: configOpts.SigningContext,
+		})
+	}, nil)
+
+	RegisterSignMode(signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, func(configOpts ConfigOptions) (txsigning.SignModeHandler, error) {
+		return aminojson.NewSignModeHandler(aminojson.SignModeHandlerOptions{
+			FileResolver: configOpts.SigningOptions.FileResolver,
+			TypeResolver: configOpts.SigningOptions.TypeResolver,
+		}), nil
+	}, nil)
+
+	RegisterSignMode(signingtypes.SignMode_SIGN_MODE_TEXTUAL, func(configOpts ConfigOptions) (txsigning.SignModeHandler, error) {
+		if configOpts.TextualCoinMetadataQueryFn == nil {
+			return nil, fmt.Errorf("cannot enable SIGN_MODE_TEXTUAL without a TextualCoinMetadataQueryFn")
+		}
+		return textual.NewSignModeHandler(textual.SignModeOptions{
+			CoinMetadataQuerier: configOpts.TextualCoinMetadataQueryFn,
+			FileResolver:        configOpts.SigningOptions.FileResolver,
+			TypeResolver:        configOpts.SigningOptions.TypeResolver,
+		})
+	}, nil)
+}
+
 type config struct {
-	handler     signing.SignModeHandler
-	decoder     sdk.TxDecoder
-	encoder     sdk.TxEncoder
-	jsonDecoder sdk.TxDecoder
-	jsonEncoder sdk.TxEncoder
-	protoCodec  codec.ProtoCodecMarshaler
-}
-
-// NewTxConfig returns a new protobuf TxConfig using the provided ProtoCodec and sign modes. The
-// first enabled sign mode will become the default sign mode.
-Updated upstream
-// NOTE: Use NewTxConfigWithHandler to provide a custom signing handler in case the sign mode
-// is not supported by default (eg: SignMode_SIGN_MODE_EIP_191).
-func NewTxConfig(protoCodec codec.ProtoCodecMarshaler, enabledSignModes []signingtypes.SignMode) client.TxConfig {
-	return NewTxConfigWithHandler(protoCodec, makeSignModeHandler(enabledSignModes))
+	handler       signing.SignModeHandler
+	decoder       sdk.TxDecoder
+	encoder       sdk.TxEncoder
+	jsonDecoder   sdk.TxDecoder
+	jsonEncoder   sdk.TxEncoder
+	streamDecoder sdk.TxStreamDecoder
+	streamEncoder sdk.TxStreamEncoder
+	protoCodec    codec.ProtoCodecMarshaler
+	builderHooks  []TxBuilderHook
+}
 
+// NewTxConfig returns a new protobuf TxConfig using the provided ProtoCodec, sign modes and,
+// optionally, fully constructed custom sign mode handlers.
 //
-// NOTE: Use NewTxConfigWithOptions to provide a custom signing handler in case the sign mode
-// is not supported by default (eg: SignMode_SIGN_MODE_EIP_191), or to enable SIGN_MODE_TEXTUAL.
+// NOTE: Use NewTxConfigWithOptions to enable SIGN_MODE_TEXTUAL, or RegisterSignMode to add a
+// reusable sign mode (eg: SignMode_SIGN_MODE_EIP_712) without forking this package.
 //
 // We prefer to use depinject to provide client.TxConfig, but we permit this constructor usage. Within the SDK,
 // this constructor is primarily used in tests, but also sees usage in app chains like:
@@ -47,6 +154,65 @@ func NewTxConfig(protoCodec codec.Codec, enabledSignModes []signingtypes.SignMod
 	return txConfig
 }
 
+// NewTxConfigWithHandler returns a new protobuf TxConfig using the provided ProtoCodec and an
+// already constructed signing handler, bypassing the sign mode registry entirely.
+func NewTxConfigWithHandler(protoCodec codec.ProtoCodecMarshaler, handler signing.SignModeHandler) client.TxConfig {
+	return &config{
+		handler:       handler,
+		decoder:       DefaultTxDecoder(protoCodec),
+		encoder:       DefaultTxEncoder(),
+		jsonDecoder:   DefaultJSONTxDecoder(protoCodec),
+		jsonEncoder:   DefaultJSONTxEncoder(protoCodec),
+		streamDecoder: DefaultTxStreamDecoder(protoCodec),
+		streamEncoder: DefaultTxStreamEncoder(protoCodec),
+		protoCodec:    protoCodec,
+	}
+}
+
+// NewTxConfigWithOptions returns a new protobuf TxConfig using the provided ProtoCodec and
+// ConfigOptions. Sign mode handlers are resolved against the registry populated by
+// RegisterSignMode (plus ConfigOptions.CustomSignModes), and any TxBuilderHook registered
+// against an enabled sign mode is applied to every TxBuilder returned by NewTxBuilder or
+// WrapTxBuilder.
+func NewTxConfigWithOptions(protoCodec codec.Codec, configOptions ConfigOptions) (client.TxConfig, error) {
+	handlers, builderHooks, err := buildSignModeHandlers(configOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &config{
+		handler:       txsigning.NewHandlerMap(handlers...),
+		decoder:       DefaultTxDecoder(protoCodec),
+		encoder:       DefaultTxEncoder(),
+		jsonDecoder:   DefaultJSONTxDecoder(protoCodec),
+		jsonEncoder:   DefaultJSONTxEncoder(protoCodec),
+		streamDecoder: DefaultTxStreamDecoder(protoCodec),
+		streamEncoder: DefaultTxStreamEncoder(protoCodec),
+		protoCodec:    protoCodec,
+		builderHooks:  builderHooks,
+	}
+
+	if configOptions.ProtoDecoder != nil {
+		cfg.decoder = configOptions.ProtoDecoder
+	}
+	if configOptions.ProtoEncoder != nil {
+		cfg.encoder = configOptions.ProtoEncoder
+	}
+	if configOptions.JSONDecoder != nil {
+		cfg.jsonDecoder = configOptions.JSONDecoder
+	}
+	if configOptions.JSONEncoder != nil {
+		cfg.jsonEncoder = configOptions.JSONEncoder
+	}
+
+	if configOptions.TxCache != nil && configOptions.TxCache.Size > 0 {
+		cfg.decoder = withCachedDecoder(cfg.decoder, *configOptions.TxCache)
+		cfg.handler = withCachedSignModeHandler(cfg.handler, cfg.encoder, *configOptions.TxCache)
+	}
+
+	return cfg, nil
+}
+
 // NewDefaultSigningOptions returns the sdk default signing options used by x/tx.  This includes account and
 // validator address prefix enabled codecs.
 func NewDefaultSigningOptions() (*txsigning.Options, error) {
@@ -58,86 +224,69 @@ func NewDefaultSigningOptions() (*txsigning.Options, error) {
 }
 
 // NewSigningHandlerMap returns a new txsigning.HandlerMap using the provided ConfigOptions.
-// It is recommended to use types.InterfaceRegistry in the field ConfigOptions.FileResolver as shown in
+// It is recommended to use types.InterfaceRegistry in the field ConfigOptions.SigningOptions as shown in
 // NewTxConfigWithOptions but this fn does not enforce it.
 func NewSigningHandlerMap(configOpts ConfigOptions) (*txsigning.HandlerMap, error) {
+	handlers, _, err := buildSignModeHandlers(configOpts)
+	if err != nil {
+		return nil, err
+	}
+	return txsigning.NewHandlerMap(handlers...), nil
+}
+
+// buildSignModeHandlers resolves configOpts.EnabledSignModes against the sign mode registry
+// populated via RegisterSignMode, appends configOpts.CustomSignModes, and returns both the
+// resulting handlers and the builderHooks registered against the enabled modes, in the order the
+// modes were enabled.
+func buildSignModeHandlers(configOpts ConfigOptions) ([]txsigning.SignModeHandler, []TxBuilderHook, error) {
 	var err error
 	if configOpts.SigningOptions == nil {
 		configOpts.SigningOptions, err = NewDefaultSigningOptions()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 	if configOpts.SigningContext == nil {
 		configOpts.SigningContext, err = txsigning.NewContext(*configOpts.SigningOptions)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
-	signingOpts := configOpts.SigningOptions
-
 	if len(configOpts.EnabledSignModes) == 0 {
 		configOpts.EnabledSignModes = DefaultSignModes
 	}
 
 	lenSignModes := len(configOpts.EnabledSignModes)
 	handlers := make([]txsigning.SignModeHandler, lenSignModes+len(configOpts.CustomSignModes))
+	var builderHooks []TxBuilderHook
+
+	signModeRegistryMu.RLock()
+	defer signModeRegistryMu.RUnlock()
+
 	for i, m := range configOpts.EnabledSignModes {
-		var err error
-		switch m {
-		case signingtypes.SignMode_SIGN_MODE_DIRECT:
-			handlers[i] = &direct.SignModeHandler{}
-		case signingtypes.SignMode_SIGN_MODE_DIRECT_AUX:
-			handlers[i], err = directaux.NewSignModeHandler(directaux.SignModeHandlerOptions{
-				TypeResolver:   signingOpts.TypeResolver,
-				SignersContext: configOpts.SigningContext,
-			})
-			if err != nil {
-				return nil, err
-			}
-		case signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON:
-			handlers[i] = aminojson.NewSignModeHandler(aminojson.SignModeHandlerOptions{
-				FileResolver: signingOpts.FileResolver,
-				TypeResolver: signingOpts.TypeResolver,
-			})
-		case signingtypes.SignMode_SIGN_MODE_TEXTUAL:
-			handlers[i], err = textual.NewSignModeHandler(textual.SignModeOptions{
-				CoinMetadataQuerier: configOpts.TextualCoinMetadataQueryFn,
-				FileResolver:        signingOpts.FileResolver,
-				TypeResolver:        signingOpts.TypeResolver,
-			})
-			if configOpts.TextualCoinMetadataQueryFn == nil {
-				return nil, fmt.Errorf("cannot enable SIGN_MODE_TEXTUAL without a TextualCoinMetadataQueryFn")
-			}
-			if err != nil {
-				return nil, err
-			}
+		reg, ok := signModeRegistry[m]
+		if !ok {
+			return nil, nil, fmt.Errorf("sign mode %s is not registered: use RegisterSignMode or ConfigOptions.CustomSignModes", m)
+		}
+
+		handlers[i], err = reg.factory(configOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+		if reg.builderHook != nil {
+			builderHooks = append(builderHooks, reg.builderHook)
 		}
 	}
 	for i, m := range configOpts.CustomSignModes {
 		handlers[i+lenSignModes] = m
 	}
 
-	handler := txsigning.NewHandlerMap(handlers...)
-	return handler, nil
-Stashed changes
-}
-
-// NewTxConfig returns a new protobuf TxConfig using the provided ProtoCodec and signing handler.
-func NewTxConfigWithHandler(protoCodec codec.ProtoCodecMarshaler, handler signing.SignModeHandler) client.TxConfig {
-	return &config{
-		handler:     handler,
-		decoder:     DefaultTxDecoder(protoCodec),
-		encoder:     DefaultTxEncoder(),
-		jsonDecoder: DefaultJSONTxDecoder(protoCodec),
-		jsonEncoder: DefaultJSONTxEncoder(protoCodec),
-		protoCodec:  protoCodec,
-	}
+	return handlers, builderHooks, nil
 }
 
 func (g config) NewTxBuilder() client.TxBuilder {
-	return newBuilder(g.protoCodec)
+	return g.applyBuilderHooks(newBuilder(g.protoCodec))
 }
 
 // WrapTxBuilder returns a builder from provided transaction
@@ -147,7 +296,17 @@ func (g config) WrapTxBuilder(newTx sdk.Tx) (client.TxBuilder, error) {
 		return nil, fmt.Errorf("expected %T, got %T", &wrapper{}, newTx)
 	}
 
-	return newBuilder, nil
+	return g.applyBuilderHooks(newBuilder), nil
+}
+
+// applyBuilderHooks runs every enabled sign mode's TxBuilderHook over builder, in the order the
+// modes are listed in EnabledSignModes, so a wrapped builder (WrapTxBuilder) gets the same mode-specific fields - e.g. an
+// EIP-712 typed-data domain - as a freshly constructed one (NewTxBuilder).
+func (g config) applyBuilderHooks(builder client.TxBuilder) client.TxBuilder {
+	for _, hook := range g.builderHooks {
+		builder = hook(builder)
+	}
+	return builder
 }
 
 func (g config) SignModeHandler() signing.SignModeHandler {
@@ -169,3 +328,17 @@ func (g config) TxJSONEncoder() sdk.TxEncoder {
 func (g config) TxJSONDecoder() sdk.TxDecoder {
 	return g.jsonDecoder
 }
+
+// TxStreamEncoder returns a sdk.TxStreamEncoder that writes txs to an io.Writer one at a time
+// using length-prefixed proto framing, for tooling that needs to produce genesis exports, block
+// archives, or indexer backfills without buffering every tx in memory at once.
+func (g config) TxStreamEncoder() sdk.TxStreamEncoder {
+	return g.streamEncoder
+}
+
+// TxStreamDecoder returns a sdk.TxStreamDecoder that reads txs from an io.Reader one at a time
+// using length-prefixed proto framing. Prefer NewTxIterator over calling it directly when reading
+// a full stream.
+func (g config) TxStreamDecoder() sdk.TxStreamDecoder {
+	return g.streamDecoder
+}
@@ -0,0 +1,48 @@
+package tx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	signingtypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+func TestBuildSignModeHandlersUnregisteredModeErrors(t *testing.T) {
+	const unregistered = signingtypes.SignMode(9999)
+
+	_, _, err := buildSignModeHandlers(ConfigOptions{EnabledSignModes: []signingtypes.SignMode{unregistered}})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "not registered")
+}
+
+// TestApplyBuilderHooksOrder exercises the helper shared by NewTxBuilder and WrapTxBuilder,
+// asserting every TxBuilderHook in builderHooks runs, in slice order, regardless of which
+// constructor produced the builder.
+func TestApplyBuilderHooksOrder(t *testing.T) {
+	var order []string
+	hookA := func(b client.TxBuilder) client.TxBuilder {
+		order = append(order, "a")
+		return b
+	}
+	hookB := func(b client.TxBuilder) client.TxBuilder {
+		order = append(order, "b")
+		return b
+	}
+
+	cfg := config{builderHooks: []TxBuilderHook{hookA, hookB}}
+
+	var builder client.TxBuilder
+	got := cfg.applyBuilderHooks(builder)
+
+	require.Equal(t, builder, got)
+	require.Equal(t, []string{"a", "b"}, order)
+}
+
+func TestApplyBuilderHooksNoHooksIsNoop(t *testing.T) {
+	cfg := config{}
+
+	var builder client.TxBuilder
+	require.Equal(t, builder, cfg.applyBuilderHooks(builder))
+}
@@ -0,0 +1,174 @@
+package tx
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// maxTxStreamSize bounds the length prefix read by decodeFrame so a corrupt or malicious stream
+// cannot force an unbounded allocation.
+const maxTxStreamSize = 1 << 20 // 1 MiB, matches CometBFT's default mempool.max_tx_bytes
+
+// DefaultTxStreamEncoder returns a sdk.TxStreamEncoder that encodes each tx with
+// DefaultTxEncoder (protoCodec is not used) and writes it to w as a uvarint length prefix followed
+// by the encoded bytes. It is
+// the streaming counterpart to DefaultTxEncoder, intended for writing genesis exports, block
+// archives, or indexer backfills containing large numbers of txs without holding them all in
+// memory at once.
+func DefaultTxStreamEncoder(protoCodec codec.ProtoCodecMarshaler) sdk.TxStreamEncoder {
+	encode := DefaultTxEncoder()
+	return func(w io.Writer, tx sdk.Tx) error {
+		bz, err := encode(tx)
+		if err != nil {
+			return err
+		}
+		return encodeFrame(w, bz)
+	}
+}
+
+// DefaultTxStreamDecoder returns a sdk.TxStreamDecoder that reads a uvarint length prefix
+// followed by that many bytes from r and proto-unmarshals them with protoCodec. It is the
+// streaming counterpart to DefaultTxDecoder, used by TxIterator to read genesis exports, block
+// archives, or indexer backfills containing large numbers of txs without buffering the whole
+// input.
+//
+// It returns io.EOF when r is exhausted exactly at a frame boundary, and a non-EOF error for a
+// truncated or oversized frame.
+func DefaultTxStreamDecoder(protoCodec codec.ProtoCodecMarshaler) sdk.TxStreamDecoder {
+	decode := DefaultTxDecoder(protoCodec)
+	return func(r io.Reader) (sdk.Tx, error) {
+		bz, err := decodeFrame(r)
+		if err != nil {
+			return nil, err
+		}
+		return decode(bz)
+	}
+}
+
+// encodeFrame writes bz to w as a uvarint length prefix followed by bz itself.
+func encodeFrame(w io.Writer, bz []byte) error {
+	var lenBuf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBuf[:], uint64(len(bz)))
+	if _, err := w.Write(lenBuf[:n]); err != nil {
+		return err
+	}
+	_, err := w.Write(bz)
+	return err
+}
+
+// decodeFrame reads a uvarint length prefix followed by that many bytes from r. It returns io.EOF
+// when r is exhausted exactly at a frame boundary, and a non-EOF error for a truncated or
+// oversized frame.
+func decodeFrame(r io.Reader) ([]byte, error) {
+	size, err := readUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxTxStreamSize {
+		return nil, fmt.Errorf("tx size %d exceeds maximum stream tx size %d", size, maxTxStreamSize)
+	}
+
+	bz := make([]byte, size)
+	if _, err := io.ReadFull(r, bz); err != nil {
+		if err == io.EOF {
+			// the stream ended right after the length prefix: a truncated frame, not a clean end
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return bz, nil
+}
+
+// readUvarint reads a uvarint from r one byte at a time, so that r is left positioned exactly
+// after the varint with no bytes consumed past it (unlike binary.ReadUvarint, which requires an
+// io.ByteReader and must not be handed a fresh buffered reader on every call without losing any
+// bytes it read ahead).
+func readUvarint(r io.Reader) (uint64, error) {
+	var x uint64
+	var s uint
+	var b [1]byte
+	for i := 0; i < binary.MaxVarintLen64; i++ {
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			if i == 0 {
+				return 0, err // propagate io.EOF at a frame boundary
+			}
+			return 0, fmt.Errorf("reading length prefix: %w", err)
+		}
+		if b[0] < 0x80 {
+			return x | uint64(b[0])<<s, nil
+		}
+		x |= uint64(b[0]&0x7f) << s
+		s += 7
+	}
+	return 0, fmt.Errorf("length prefix overflows uint64")
+}
+
+// TxIterator yields transactions one at a time from a reader containing a sequence of
+// length-prefixed, proto-encoded txs written by a sdk.TxStreamEncoder. It is intended for tooling
+// that processes genesis exports, block archives, or large indexer backfills with tens of
+// thousands of txs, where decoding via sdk.TxDecoder would require buffering the whole input.
+//
+// A TxIterator is not safe for concurrent use.
+type TxIterator struct {
+	r      io.Reader
+	decode sdk.TxStreamDecoder
+}
+
+// NewTxIterator returns a TxIterator that reads successive txs out of r using decode.
+func NewTxIterator(r io.Reader, decode sdk.TxStreamDecoder) *TxIterator {
+	return &TxIterator{r: r, decode: decode}
+}
+
+// Next returns the next tx from the underlying reader, or io.EOF once the stream is exhausted.
+func (it *TxIterator) Next() (sdk.Tx, error) {
+	return it.decode(it.r)
+}
+
+// TxJSONIterator yields transactions one at a time from a reader containing a top-level JSON
+// array of txs encoded with sdk.TxJSONEncoder, using json.Decoder token streaming so the array is
+// never buffered in full.
+//
+// A TxJSONIterator is not safe for concurrent use.
+type TxJSONIterator struct {
+	dec    *json.Decoder
+	decode sdk.TxDecoder
+	opened bool
+}
+
+// NewTxJSONIterator returns a TxJSONIterator that reads successive txs out of the JSON array in
+// r, decoding each element with decode (typically DefaultJSONTxDecoder).
+func NewTxJSONIterator(r io.Reader, decode sdk.TxDecoder) *TxJSONIterator {
+	return &TxJSONIterator{dec: json.NewDecoder(r), decode: decode}
+}
+
+// Next returns the next tx from the underlying JSON array, or io.EOF once the array is exhausted.
+func (it *TxJSONIterator) Next() (sdk.Tx, error) {
+	if !it.opened {
+		tok, err := it.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return nil, fmt.Errorf("expected a JSON array of transactions, got %v", tok)
+		}
+		it.opened = true
+	}
+
+	if !it.dec.More() {
+		if _, err := it.dec.Token(); err != nil { // consume the closing ']'
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+
+	var raw json.RawMessage
+	if err := it.dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+	return it.decode(raw)
+}
@@ -0,0 +1,90 @@
+package tx
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestEncodeDecodeFrameRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	payload := []byte("hello world")
+
+	require.NoError(t, encodeFrame(&buf, payload))
+
+	got, err := decodeFrame(&buf)
+	require.NoError(t, err)
+	require.Equal(t, payload, got)
+}
+
+func TestDecodeFrameEmptyReaderReturnsEOF(t *testing.T) {
+	_, err := decodeFrame(bytes.NewReader(nil))
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestDecodeFrameTruncatedPayload(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, encodeFrame(&buf, []byte("hello world")))
+
+	truncated := bytes.NewReader(buf.Bytes()[:buf.Len()-3])
+
+	_, err := decodeFrame(truncated)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, io.EOF)
+}
+
+func TestDecodeFrameOversizedRejected(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, encodeFrame(&buf, make([]byte, maxTxStreamSize+1)))
+
+	_, err := decodeFrame(&buf)
+	require.Error(t, err)
+	require.ErrorContains(t, err, "exceeds maximum stream tx size")
+}
+
+func TestTxIteratorEOF(t *testing.T) {
+	calls := 0
+	decode := func(io.Reader) (sdk.Tx, error) {
+		calls++
+		if calls > 2 {
+			return nil, io.EOF
+		}
+		return nil, nil
+	}
+
+	it := NewTxIterator(bytes.NewReader(nil), decode)
+	for i := 0; i < 2; i++ {
+		_, err := it.Next()
+		require.NoError(t, err)
+	}
+
+	_, err := it.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestTxJSONIteratorEOF(t *testing.T) {
+	r := strings.NewReader(`[{"a":1},{"a":2}]`)
+	decode := func(bz []byte) (sdk.Tx, error) { return nil, nil }
+
+	it := NewTxJSONIterator(r, decode)
+	for i := 0; i < 2; i++ {
+		_, err := it.Next()
+		require.NoError(t, err)
+	}
+
+	_, err := it.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestTxJSONIteratorRejectsNonArray(t *testing.T) {
+	r := strings.NewReader(`{"a":1}`)
+	it := NewTxJSONIterator(r, func(bz []byte) (sdk.Tx, error) { return nil, nil })
+
+	_, err := it.Next()
+	require.Error(t, err)
+}